@@ -0,0 +1,165 @@
+// Package graph => the resolved dependencies as nodes and edges, so Go
+// consumers can run their own analyses instead of re-walking Transitive
+// slices; nested_dep_check builds one per scan (BuildGraph, -graph-out)
+package graph
+
+import (
+    "fmt"
+    "maps"
+    "slices"
+)
+
+// Node => one package version; ID is "ecosystem:name@version"
+type Node struct {
+    ID        string `json:"id"`
+    Ecosystem string `json:"ecosystem"`
+    Name      string `json:"name"`
+    Version   string `json:"version"`
+    License   string `json:"license"`
+    Direct    bool   `json:"direct,omitempty"`
+}
+
+// Edge => From depends on To
+type Edge struct {
+    From string `json:"from"`
+    To   string `json:"to"`
+}
+
+// Graph => a package reached from several parents is one node with several
+// in-edges; edges keep the order they were added in
+type Graph struct {
+    Nodes map[string]*Node
+    Edges []Edge
+    out   map[string][]string
+    in    map[string][]string
+}
+
+// New => an empty graph
+func New() *Graph {
+    return &Graph{Nodes: make(map[string]*Node), out: make(map[string][]string), in: make(map[string][]string)}
+}
+
+// ID => the node ID for one package version
+func ID(eco, name, version string) string {
+    return eco + ":" + name + "@" + version
+}
+
+// AddNode => add n (ID filled in) unless a node with its ID exists; returns
+// the ID either way
+func (g *Graph) AddNode(n Node) string {
+    n.ID = ID(n.Ecosystem, n.Name, n.Version)
+    if _, ok := g.Nodes[n.ID]; !ok {
+        g.Nodes[n.ID] = &n
+    }
+    return n.ID
+}
+
+// AddEdge => from depends on to; repeats are dropped
+func (g *Graph) AddEdge(from, to string) {
+    if slices.Contains(g.out[from], to) {
+        return
+    }
+    g.Edges = append(g.Edges, Edge{From: from, To: to})
+    g.out[from] = append(g.out[from], to)
+    g.in[to] = append(g.in[to], from)
+}
+
+// Dependencies => direct dependencies of id
+func (g *Graph) Dependencies(id string) []string { return g.out[id] }
+
+// Dependents => packages that depend on id directly
+func (g *Graph) Dependents(id string) []string { return g.in[id] }
+
+// Roots => the direct dependencies of the project, sorted
+func (g *Graph) Roots() []string {
+    var out []string
+    for id, n := range g.Nodes {
+        if n.Direct {
+            out = append(out, id)
+        }
+    }
+    slices.Sort(out)
+    return out
+}
+
+// TopoSort => every node after all of its dependencies (leaves first), ties
+// by ID; an error names a node on a cycle
+func (g *Graph) TopoSort() ([]string, error) {
+    pending := make(map[string]int, len(g.Nodes))
+    var ready []string
+    for id := range g.Nodes {
+        if pending[id] = len(g.out[id]); pending[id] == 0 {
+            ready = append(ready, id)
+        }
+    }
+    slices.Sort(ready)
+    order := make([]string, 0, len(g.Nodes))
+    for len(ready) > 0 {
+        id := ready[0]
+        ready = ready[1:]
+        order = append(order, id)
+        var next []string
+        for _, p := range g.in[id] {
+            if pending[p]--; pending[p] == 0 {
+                next = append(next, p)
+            }
+        }
+        slices.Sort(next)
+        ready = append(ready, next...)
+    }
+    if len(order) < len(g.Nodes) {
+        for _, id := range slices.Sorted(maps.Keys(g.Nodes)) {
+            if pending[id] > 0 {
+                return order, fmt.Errorf("dependency cycle through %s", id)
+            }
+        }
+    }
+    return order, nil
+}
+
+// PathsTo => every path from a root to id, each root first; at most limit
+// paths (0 = all); paths through a cycle stop at the repeat
+func (g *Graph) PathsTo(id string, limit int) [][]string {
+    var paths [][]string
+    var walk func(cur string, suffix []string)
+    walk = func(cur string, suffix []string) {
+        if limit > 0 && len(paths) >= limit || slices.Contains(suffix, cur) {
+            return
+        }
+        suffix = append([]string{cur}, suffix...)
+        if n := g.Nodes[cur]; n != nil && n.Direct {
+            paths = append(paths, suffix)
+        }
+        for _, p := range g.in[cur] {
+            walk(p, suffix)
+        }
+    }
+    if g.Nodes[id] != nil {
+        walk(id, nil)
+    }
+    return paths
+}
+
+// Subgraph => the nodes reachable from ids (ids included) and the edges
+// between them; Direct is kept as in g
+func (g *Graph) Subgraph(ids ...string) *Graph {
+    sub := New()
+    queue := slices.Clone(ids)
+    for len(queue) > 0 {
+        id := queue[0]
+        queue = queue[1:]
+        n := g.Nodes[id]
+        if n == nil || sub.Nodes[id] != nil {
+            continue
+        }
+        cp := *n
+        sub.Nodes[id] = &cp
+        queue = append(queue, g.out[id]...)
+    }
+    for _, e := range g.Edges {
+        if sub.Nodes[e.From] != nil && sub.Nodes[e.To] != nil {
+            sub.AddEdge(e.From, e.To)
+        }
+    }
+    return sub
+}
@@ -0,0 +1,96 @@
+package graph
+
+import (
+    "slices"
+    "testing"
+)
+
+// diamond => app-a and app-b (direct) both depend on shared, which depends
+// on leaf; app-a also depends on leaf directly
+func diamond() *Graph {
+    g := New()
+    a := g.AddNode(Node{Ecosystem: "node", Name: "app-a", Version: "1.0.0", Direct: true})
+    b := g.AddNode(Node{Ecosystem: "node", Name: "app-b", Version: "1.0.0", Direct: true})
+    shared := g.AddNode(Node{Ecosystem: "node", Name: "shared", Version: "2.0.0"})
+    leaf := g.AddNode(Node{Ecosystem: "node", Name: "leaf", Version: "3.0.0"})
+    g.AddEdge(a, shared)
+    g.AddEdge(b, shared)
+    g.AddEdge(shared, leaf)
+    g.AddEdge(a, leaf)
+    g.AddEdge(a, leaf)
+    return g
+}
+
+func TestTopoSort(t *testing.T) {
+    g := diamond()
+    order, err := g.TopoSort()
+    if err != nil {
+        t.Fatal(err)
+    }
+    want := []string{"node:leaf@3.0.0", "node:shared@2.0.0", "node:app-a@1.0.0", "node:app-b@1.0.0"}
+    if !slices.Equal(order, want) {
+        t.Errorf("TopoSort = %v, want %v", order, want)
+    }
+    if len(g.Edges) != 4 {
+        t.Errorf("%d edges, want 4 (repeat dropped)", len(g.Edges))
+    }
+
+    g.AddEdge("node:leaf@3.0.0", "node:shared@2.0.0")
+    if _, err := g.TopoSort(); err == nil {
+        t.Error("TopoSort on a cycle: no error")
+    }
+}
+
+func TestPathsTo(t *testing.T) {
+    g := diamond()
+    paths := g.PathsTo("node:leaf@3.0.0", 0)
+    want := [][]string{
+        {"node:app-a@1.0.0", "node:shared@2.0.0", "node:leaf@3.0.0"},
+        {"node:app-b@1.0.0", "node:shared@2.0.0", "node:leaf@3.0.0"},
+        {"node:app-a@1.0.0", "node:leaf@3.0.0"},
+    }
+    if !slices.EqualFunc(paths, want, slices.Equal) {
+        t.Errorf("PathsTo(leaf) = %v, want %v", paths, want)
+    }
+    if got := g.PathsTo("node:leaf@3.0.0", 1); len(got) != 1 {
+        t.Errorf("PathsTo(leaf, 1) = %d paths, want 1", len(got))
+    }
+    if got := g.PathsTo("node:app-b@1.0.0", 0); len(got) != 1 || len(got[0]) != 1 {
+        t.Errorf("PathsTo(root) = %v, want the root alone", got)
+    }
+    if got := g.PathsTo("node:missing@0", 0); got != nil {
+        t.Errorf("PathsTo(missing) = %v, want none", got)
+    }
+
+    g.AddEdge("node:leaf@3.0.0", "node:shared@2.0.0")
+    if got := g.PathsTo("node:leaf@3.0.0", 0); len(got) != 3 {
+        t.Errorf("PathsTo(leaf) with a cycle = %d paths, want 3", len(got))
+    }
+}
+
+func TestSubgraph(t *testing.T) {
+    g := diamond()
+    sub := g.Subgraph("node:app-b@1.0.0")
+    want := []string{"node:app-b@1.0.0", "node:leaf@3.0.0", "node:shared@2.0.0"}
+    var got []string
+    for id := range sub.Nodes {
+        got = append(got, id)
+    }
+    slices.Sort(got)
+    if !slices.Equal(got, want) {
+        t.Errorf("Subgraph nodes = %v, want %v", got, want)
+    }
+    if len(sub.Edges) != 2 {
+        t.Errorf("Subgraph edges = %v, want app-b->shared and shared->leaf", sub.Edges)
+    }
+    if !slices.Equal(sub.Roots(), []string{"node:app-b@1.0.0"}) {
+        t.Errorf("Subgraph roots = %v", sub.Roots())
+    }
+    if !slices.Equal(sub.Dependents("node:shared@2.0.0"), []string{"node:app-b@1.0.0"}) {
+        t.Errorf("Subgraph dependents of shared = %v", sub.Dependents("node:shared@2.0.0"))
+    }
+    sub.Nodes["node:leaf@3.0.0"].License = "MIT"
+    if g.Nodes["node:leaf@3.0.0"].License != "" {
+        t.Error("Subgraph shares nodes with the original graph")
+    }
+}